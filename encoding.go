@@ -0,0 +1,58 @@
+package goini
+
+import (
+	"bytes"
+	"io"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/unicode"
+)
+
+var (
+	bomUTF8    = []byte{0xEF, 0xBB, 0xBF}
+	bomUTF16LE = []byte{0xFF, 0xFE}
+	bomUTF16BE = []byte{0xFE, 0xFF}
+)
+
+// LoadFromReader reads all of r and loads it the same way LoadFromBytes
+// does, BOM detection included.
+func (i *Ini) LoadFromReader(r io.Reader) error {
+	buffer, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return i.LoadFromBytes(buffer)
+}
+
+// LoadFromBytesWithEncoding decodes buffer with enc before loading it. Use
+// this for ini files in a fixed legacy encoding, such as Windows-1252 or
+// ISO-8859-1, that carry no BOM for LoadFromBytes to autodetect.
+func (i *Ini) LoadFromBytesWithEncoding(buffer []byte, enc encoding.Encoding) error {
+	decoded, err := enc.NewDecoder().Bytes(buffer)
+	if err != nil {
+		return err
+	}
+	i.clear()
+	i.buffer = decoded
+	i.parseLines()
+	return nil
+}
+
+// decodeBOM strips a UTF-8 BOM, or transcodes a UTF-16 BOM-prefixed buffer
+// to UTF-8. A buffer with no recognized BOM is returned unchanged.
+func decodeBOM(buffer []byte) ([]byte, error) {
+	switch {
+	case bytes.HasPrefix(buffer, bomUTF8):
+		return buffer[len(bomUTF8):], nil
+	case bytes.HasPrefix(buffer, bomUTF16LE):
+		return transcodeUTF16(buffer, unicode.LittleEndian)
+	case bytes.HasPrefix(buffer, bomUTF16BE):
+		return transcodeUTF16(buffer, unicode.BigEndian)
+	default:
+		return buffer, nil
+	}
+}
+
+func transcodeUTF16(buffer []byte, endian unicode.Endianness) ([]byte, error) {
+	return unicode.UTF16(endian, unicode.ExpectBOM).NewDecoder().Bytes(buffer)
+}
@@ -0,0 +1,65 @@
+package goini
+
+import "strings"
+
+// unescapeReplacer expands the backslash escapes recognized inside a value:
+// \; \# \= for the characters that would otherwise need quoting, \\ for a
+// literal backslash, and \n \t \r for the usual control characters.
+var unescapeReplacer = strings.NewReplacer(
+	`\;`, ";",
+	`\#`, "#",
+	`\=`, "=",
+	`\n`, "\n",
+	`\t`, "\t",
+	`\r`, "\r",
+	`\\`, "\\",
+)
+
+func unescapeValue(value string) string {
+	return unescapeReplacer.Replace(value)
+}
+
+// escapeReplacer is the inverse of unescapeReplacer, used when serializing
+// a value back out so it round-trips through unescapeValue unchanged.
+var escapeReplacer = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	"#", `\#`,
+	"\n", `\n`,
+	"\t", `\t`,
+	"\r", `\r`,
+)
+
+func escapeValue(value string) string {
+	return escapeReplacer.Replace(value)
+}
+
+// splitInlineComment splits value at the first unescaped ';' or '#' that is
+// preceded by whitespace, returning the value part (with the comment and
+// its leading whitespace removed) and the raw comment part, marker
+// included. It returns value unchanged with an empty comment if no such
+// marker is found.
+func splitInlineComment(value string) (string, string) {
+	for idx := 0; idx < len(value); idx++ {
+		c := value[idx]
+		if c != ';' && c != '#' {
+			continue
+		}
+		if countTrailingBackslashes(value[:idx])%2 == 1 {
+			continue // the marker itself is escaped
+		}
+		if idx == 0 || (value[idx-1] != ' ' && value[idx-1] != '\t') {
+			continue // must be preceded by unescaped whitespace
+		}
+		return strings.TrimRight(value[:idx], " \t"), value[idx:]
+	}
+	return value, ""
+}
+
+func countTrailingBackslashes(s string) int {
+	count := 0
+	for i := len(s) - 1; i >= 0 && s[i] == '\\'; i-- {
+		count++
+	}
+	return count
+}
@@ -11,17 +11,46 @@ import (
 
 type KeyValue struct {
 	Item map[string]string
+
+	// pairs keeps every key/value declared in file order, duplicates
+	// included. Item is derived from it (last-declared value wins) so
+	// existing lookups keep working unchanged.
+	pairs []kvPair
+}
+
+type kvPair struct {
+	Key   string
+	Value string
 }
 
 type Ini struct {
 	fileName string
 	buffer   []byte
 	sections map[string]KeyValue
+	meta     *iniMeta
+	options  ParseOptions
+}
+
+// ParseOptions controls how LoadFromFile/LoadFromBytes interpret raw ini
+// text. The zero value matches the historical behaviour of this package:
+// inline comments are recognized and stripped.
+type ParseOptions struct {
+	// DisableInlineComments treats ';' and '#' found inside a value as
+	// literal characters instead of the start of an inline comment. Use
+	// this when values are expected to contain them and callers don't
+	// want to escape every occurrence with '\;' / '\#'.
+	DisableInlineComments bool
 }
 
-// NewIni creates a new Ini struct
+// NewIni creates a new Ini struct.
 func NewIni() *Ini {
+	return NewIniWithOptions(ParseOptions{})
+}
+
+// NewIniWithOptions creates a new Ini struct that parses with opts.
+func NewIniWithOptions(opts ParseOptions) *Ini {
 	result := new(Ini)
+	result.options = opts
 	result.clear()
 	return result
 }
@@ -34,16 +63,23 @@ func (i *Ini) LoadFromFile(path string) error {
 	if err != nil {
 		return err
 	}
-	i.LoadFromBytes(buffer)
-	return nil
+	return i.LoadFromBytes(buffer)
 }
 
-// LoadFromByte read the content (sections, keys and values) of an ini content
-// and keep all data inside the ini struct.
-func (i *Ini) LoadFromBytes(buffer []byte) {
+// LoadFromBytes reads the content (sections, keys and values) of an ini
+// content and keeps all data inside the ini struct. A leading UTF-8 or
+// UTF-16 BOM is detected and transcoded to UTF-8 automatically; content
+// with no BOM is assumed to already be UTF-8. It returns error if the
+// UTF-16 content can't be decoded.
+func (i *Ini) LoadFromBytes(buffer []byte) error {
+	decoded, err := decodeBOM(buffer)
+	if err != nil {
+		return err
+	}
 	i.clear()
-	i.buffer = buffer
+	i.buffer = decoded
 	i.parseLines()
+	return nil
 }
 
 // KeyExists returns true if the key inside the section exists (even if has no value).
@@ -140,6 +176,39 @@ func (i *Ini) GetStringSlice(section string, key string, def string, sep string)
 	return result
 }
 
+// GetStringValues returns every value declared for key inside section, in
+// file declaration order. A key repeated in the same section -- "include"
+// appearing once per file to pull in, "host" listed once per backend -- is
+// a common ini idiom that a single map entry can't represent. GetString
+// and friends keep returning only the last-declared value. Returns nil if
+// the key doesn't exist.
+func (i *Ini) GetStringValues(section string, key string) []string {
+	var result []string
+	for _, pair := range i.sections[section].pairs {
+		if pair.Key == key {
+			result = append(result, pair.Value)
+		}
+	}
+	return result
+}
+
+// Keys returns the keys declared in section, in file declaration order. A
+// key repeated in the file (see GetStringValues) is listed once, at its
+// first occurrence.
+func (i *Ini) Keys(section string) []string {
+	pairs := i.sections[section].pairs
+	seen := make(map[string]bool, len(pairs))
+	result := make([]string, 0, len(pairs))
+	for _, pair := range pairs {
+		if seen[pair.Key] {
+			continue
+		}
+		seen[pair.Key] = true
+		result = append(result, pair.Key)
+	}
+	return result
+}
+
 // ==========================
 // Internal methods
 // ==========================
@@ -151,20 +220,79 @@ func (i *Ini) getString(section string, key string) string {
 func (i *Ini) clear() {
 	i.sections = make(map[string]KeyValue)
 	i.buffer = []byte{}
+	i.meta = newIniMeta()
+}
+
+// setRaw stores value under section/key, creating the section if it
+// doesn't exist yet. An existing key keeps its position among pairs; a new
+// one is appended. A key loaded with duplicate declarations (see
+// GetStringValues) collapses to a single pair, at its first occurrence --
+// Set and friends deal in one value per key, same as Item.
+func (i *Ini) setRaw(section string, key string, value string) {
+	kv := i.ensureSection(section)
+	kv.Item[key] = value
+
+	found := false
+	filtered := kv.pairs[:0]
+	for _, pair := range kv.pairs {
+		if pair.Key != key {
+			filtered = append(filtered, pair)
+			continue
+		}
+		if !found {
+			pair.Value = value
+			filtered = append(filtered, pair)
+			found = true
+		}
+	}
+	kv.pairs = filtered
+	if !found {
+		kv.pairs = append(kv.pairs, kvPair{Key: key, Value: value})
+	}
+	i.sections[section] = kv
+}
+
+// appendPair stores value under section/key as a new pair, even if key was
+// already declared in section. Used while parsing, where a repeated key is
+// a duplicate to preserve rather than an update.
+func (i *Ini) appendPair(section string, key string, value string) {
+	kv := i.ensureSection(section)
+	kv.Item[key] = value
+	kv.pairs = append(kv.pairs, kvPair{Key: key, Value: value})
+	i.sections[section] = kv
+}
+
+// ensureSection returns section's KeyValue, creating it first if needed.
+func (i *Ini) ensureSection(section string) KeyValue {
+	kv, exists := i.sections[section]
+	if !exists {
+		kv = KeyValue{Item: make(map[string]string)}
+		i.sections[section] = kv
+	}
+	return kv
 }
 
 func (i *Ini) parseLines() {
-	reader := bytes.NewReader(i.buffer)
-	scanner := bufio.NewScanner(reader)
+	// Read upfront instead of line-by-line off the scanner: a value can
+	// span several raw lines (continuation, triple-quoted blocks), so the
+	// parser needs to look ahead past the line it's currently on.
+	lines := readLines(i.buffer)
 	currentSection := ""
 	i.sections[currentSection] = KeyValue{Item: make(map[string]string)}
+	i.meta.addSection(currentSection)
 
-	for scanner.Scan() {
-		line := scanner.Text()
-		line = strings.TrimSpace(line)
+	// Comments and blank lines are held back until we know what they lead
+	// into, so SaveToFile/SaveToBytes can reproduce them next to the
+	// section or key they were written for.
+	var pending []string
+
+	for idx := 0; idx < len(lines); idx++ {
+		raw := lines[idx]
+		line := strings.TrimSpace(raw)
 
 		// Empty lines or comments...
 		if line == "" || line[0] == '#' || line[0] == ';' {
+			pending = append(pending, raw)
 			continue
 		}
 
@@ -179,98 +307,39 @@ func (i *Ini) parseLines() {
 			if _, exists := i.sections[currentSection]; !exists {
 				i.sections[currentSection] = KeyValue{Item: make(map[string]string)}
 			}
+			i.meta.addSection(currentSection)
+			i.meta.setComments(currentSection, "", pending)
+			pending = nil
 
 			continue
 		}
 
-		pairs := strings.Split(line, "=")
-		// Only lines with one '=' are allowed
+		pairs := strings.SplitN(line, "=", 2)
+		// A line needs at least one '=' to be a key/value pair. Everything
+		// after the first one belongs to the value.
 		if len(pairs) != 2 {
 			continue
 		}
 		key := strings.TrimSpace(pairs[0])
-		value := strings.TrimSpace(pairs[1])
-		i.sections[currentSection].Item[key] = value
-	}
-}
-
-// ==========================
-// WORK IN PROGRESS METHODS (not tested yet)....
-// ==========================
-
-// No es posible usar esta sintaxis en un método.
-//      No compila ==> func (i *Ini) [T any]GetValue(section string, key string, def any) any
-// Si se usa con la sintaxis normal, el método de llamada tiene una sintaxis horrible:
-//      func (i *Ini) GetValue(section string, key string, def any) any
-//      Ejemplo: ini.GetValue("8 bits colors", "red", int8(0)).int8()
+		value, consumed := i.collectValue(pairs[1], lines, idx+1)
+		idx += consumed
 
-// This method has an ugly syntax: GetValue("sect", "key", int64(0)).int64()
-// A more specific method. If you wanna be more precise. IE, checks if a return
-// type (int8) does not fit in the type from the ini. "width=325"
-
-/* TODO:
-func (i *Ini) GetValue(section string, key string, def any) any {
-	defType := reflect.TypeOf(def)
-	defValue := reflect.ValueOf(def)
-	varSize := varSize(def) //int(unsafe.Sizeof(defValue.Interface()))
-
-	result := reflect.New(defType).Elem()
-
-	switch result.Kind() {
-	// Int
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		x, err := strconv.ParseInt(i.getString(section, key), 10, varSize)
-		if err != nil {
-			result.SetInt(defValue.Int())
-		} else {
-			result.SetInt(x)
-		}
-
-		// Uint
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		x, err := strconv.ParseUint(i.getString(section, key), 10, varSize)
-		if err != nil {
-			result.SetUint(defValue.Uint())
-		} else {
-			result.SetUint(x)
-		}
-
-	// Bool
-	case reflect.Bool:
-		x, err := strconv.ParseBool(i.getString(section, key))
-		if err != nil {
-			result.SetBool(defValue.Bool())
-		} else {
-			result.SetBool(x)
-		}
-
-	// Float
-	case reflect.Float32, reflect.Float64:
-		x, err := strconv.ParseFloat(i.getString(section, key), 10)
-		if err != nil {
-			result.SetFloat(defValue.Float())
-		} else {
-			result.SetFloat(x)
-		}
-
-		// String
-	case reflect.String:
-		x := i.getString(section, key)
-		result.SetString(x)
-
-	default:
-		panic("Type not implemented")
-		//return def
+		i.appendPair(currentSection, key, value)
+		i.meta.addKey(currentSection, key)
+		i.meta.setComments(currentSection, key, pending)
+		pending = nil
 	}
-	return result.Interface()
+	i.meta.trailing = pending
 }
 
-func varSize(x any) int {
-    varType := reflect.TypeOf(x)
-    varSize := varType.Size() * 8
-    return int(varSize)
+func readLines(buffer []byte) []string {
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(buffer))
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines
 }
-*/
 
 /* TODO:
 func GetSplitValues[T any](section string, key string, def T) []T {
@@ -0,0 +1,185 @@
+package goini
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// MapTo populates the fields of v, a pointer to struct, from the sections
+// and keys currently loaded in i. A nested struct field maps to a section
+// (named after the field, or overridden with a `section:"..."` tag); every
+// other top-level field maps to the empty section "".
+//
+// Field names are matched with the `ini:"key_name"` tag, falling back to
+// the field name itself. A tag of `ini:"-"` skips the field. Supported
+// field kinds are string, all int/uint widths, float32/64, bool and
+// []string (split/joined with the `delim:","` tag, "," by default).
+//
+// A missing key or a value that fails to parse leaves the field untouched,
+// matching the "default value on parse failure" behaviour of GetString,
+// GetInt and friends.
+func (i *Ini) MapTo(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("goini: MapTo requires a pointer to struct, got %T", v)
+	}
+	return i.mapStruct("", rv.Elem())
+}
+
+// ReflectFrom walks v, a struct or pointer to struct, and stores its field
+// values into the in-memory sections using the same tag rules as MapTo.
+// A field tagged `ini:"key_name,omitempty"` is skipped when it holds its
+// zero value. Call SaveToFile or SaveToBytes afterwards to persist it.
+func (i *Ini) ReflectFrom(v any) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("goini: ReflectFrom requires a struct or pointer to struct, got %T", v)
+	}
+	return i.reflectStruct("", rv)
+}
+
+func (i *Ini) mapStruct(section string, sv reflect.Value) error {
+	st := sv.Type()
+	for idx := 0; idx < st.NumField(); idx++ {
+		field := st.Field(idx)
+		if !field.IsExported() {
+			continue
+		}
+		fv := sv.Field(idx)
+
+		if fv.Kind() == reflect.Struct {
+			if err := i.mapStruct(fieldSection(field), fv); err != nil {
+				return err
+			}
+			continue
+		}
+
+		name, _, skip := fieldKey(field)
+		if skip || !i.KeyExists(section, name) {
+			continue
+		}
+		if err := setFieldFromIni(i, section, name, field, fv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *Ini) reflectStruct(section string, sv reflect.Value) error {
+	st := sv.Type()
+	for idx := 0; idx < st.NumField(); idx++ {
+		field := st.Field(idx)
+		if !field.IsExported() {
+			continue
+		}
+		fv := sv.Field(idx)
+
+		if fv.Kind() == reflect.Struct {
+			if err := i.reflectStruct(fieldSection(field), fv); err != nil {
+				return err
+			}
+			continue
+		}
+
+		name, omitempty, skip := fieldKey(field)
+		if skip || (omitempty && fv.IsZero()) {
+			continue
+		}
+		value, err := stringifyField(field, fv)
+		if err != nil {
+			return err
+		}
+		i.Set(section, name, value)
+	}
+	return nil
+}
+
+// fieldKey returns the ini key name for field, whether it carries
+// "omitempty", and whether the field should be skipped entirely.
+func fieldKey(field reflect.StructField) (name string, omitempty bool, skip bool) {
+	tag := field.Tag.Get("ini")
+	if tag == "-" {
+		return "", false, true
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = field.Name
+	}
+	return name, strings.Contains(opts, "omitempty"), false
+}
+
+// fieldSection returns the section name a nested struct field maps to.
+func fieldSection(field reflect.StructField) string {
+	if sec := field.Tag.Get("section"); sec != "" {
+		return sec
+	}
+	return field.Name
+}
+
+func setFieldFromIni(i *Ini, section string, key string, field reflect.StructField, fv reflect.Value) error {
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(i.getString(section, key))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if parsed, err := strconv.ParseInt(i.getString(section, key), 0, fv.Type().Bits()); err == nil {
+			fv.SetInt(parsed)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if parsed, err := strconv.ParseUint(i.getString(section, key), 0, fv.Type().Bits()); err == nil {
+			fv.SetUint(parsed)
+		}
+	case reflect.Float32, reflect.Float64:
+		if parsed, err := strconv.ParseFloat(i.getString(section, key), fv.Type().Bits()); err == nil {
+			fv.SetFloat(parsed)
+		}
+	case reflect.Bool:
+		fv.SetBool(i.GetBool(section, key, fv.Bool()))
+	case reflect.Slice:
+		if fv.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("goini: unsupported slice element type %s for field %s", fv.Type().Elem(), field.Name)
+		}
+		delim := field.Tag.Get("delim")
+		if delim == "" {
+			delim = ","
+		}
+		fv.Set(reflect.ValueOf(i.GetStringSlice(section, key, "", delim)))
+	default:
+		return fmt.Errorf("goini: unsupported field type %s for field %s", fv.Kind(), field.Name)
+	}
+	return nil
+}
+
+func stringifyField(field reflect.StructField, fv reflect.Value) (string, error) {
+	switch fv.Kind() {
+	case reflect.String:
+		return fv.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(fv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(fv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(fv.Float(), 'f', -1, fv.Type().Bits()), nil
+	case reflect.Bool:
+		return strconv.FormatBool(fv.Bool()), nil
+	case reflect.Slice:
+		if fv.Type().Elem().Kind() != reflect.String {
+			return "", fmt.Errorf("goini: unsupported slice element type %s for field %s", fv.Type().Elem(), field.Name)
+		}
+		delim := field.Tag.Get("delim")
+		if delim == "" {
+			delim = ","
+		}
+		items := make([]string, fv.Len())
+		for j := range items {
+			items[j] = fv.Index(j).String()
+		}
+		return strings.Join(items, delim), nil
+	default:
+		return "", fmt.Errorf("goini: unsupported field type %s for field %s", fv.Kind(), field.Name)
+	}
+}
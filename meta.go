@@ -0,0 +1,63 @@
+package goini
+
+// iniMeta tracks the parts of the original file that KeyValue's plain map
+// can't represent: section declaration order, and the comments (and blank
+// lines) that preceded a section or key. Key declaration order, duplicates
+// included, lives on KeyValue.pairs instead. It lets SaveToFile/SaveToBytes
+// reproduce a loaded file instead of emitting sections in Go's randomized
+// map order.
+type iniMeta struct {
+	sectionOrder []string
+	comments     map[string][]string // section+"\x00"+key -> raw lines preceding it
+	trailing     []string            // comments/blank lines after the last entry
+
+	seenSection map[string]bool
+}
+
+func newIniMeta() *iniMeta {
+	return &iniMeta{
+		comments:    make(map[string][]string),
+		seenSection: make(map[string]bool),
+	}
+}
+
+func commentKey(section string, key string) string {
+	return section + "\x00" + key
+}
+
+func (m *iniMeta) addSection(section string) {
+	if m.seenSection[section] {
+		return
+	}
+	m.seenSection[section] = true
+	m.sectionOrder = append(m.sectionOrder, section)
+}
+
+// addKey registers that key was declared in section, for the purposes of
+// section-order tracking. Key order itself is tracked on KeyValue.pairs.
+func (m *iniMeta) addKey(section string, key string) {
+	m.addSection(section)
+}
+
+func (m *iniMeta) setComments(section string, key string, lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+	ck := commentKey(section, key)
+	m.comments[ck] = append(m.comments[ck], lines...)
+}
+
+func (m *iniMeta) removeKey(section string, key string) {
+	delete(m.comments, commentKey(section, key))
+}
+
+func (m *iniMeta) removeSection(section string) {
+	delete(m.comments, commentKey(section, ""))
+	delete(m.seenSection, section)
+	for idx, s := range m.sectionOrder {
+		if s == section {
+			m.sectionOrder = append(m.sectionOrder[:idx], m.sectionOrder[idx+1:]...)
+			break
+		}
+	}
+}
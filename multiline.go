@@ -0,0 +1,83 @@
+package goini
+
+import "strings"
+
+// collectValue turns the value part of a "key=..." line into its final
+// string, pulling in extra raw lines for a backslash continuation or a
+// triple-quoted block. It returns the value and the number of extra lines
+// from lines[from:] it consumed.
+//
+// Precedence: a value that opens a triple-quoted block is checked first and
+// suspends all other parsing -- continuation, comments, sections, escapes --
+// until the matching closing quote, since the block is collected verbatim.
+// Any other value is then checked for continuation, line by line.
+func (i *Ini) collectValue(rawValue string, lines []string, from int) (string, int) {
+	if quote, body, ok := opensTripleQuote(rawValue); ok {
+		return collectTripleQuoted(quote, body, lines, from)
+	}
+	return i.collectContinuation(rawValue, lines, from)
+}
+
+func (i *Ini) collectContinuation(rawValue string, lines []string, from int) (string, int) {
+	value := rawValue
+	consumed := 0
+	for hasContinuationBackslash(value) {
+		if from+consumed >= len(lines) {
+			value = value[:len(value)-1]
+			break
+		}
+		value = value[:len(value)-1] + strings.TrimLeft(lines[from+consumed], " \t")
+		consumed++
+	}
+
+	if !i.options.DisableInlineComments {
+		value, _ = splitInlineComment(value)
+	}
+	return unescapeValue(strings.TrimSpace(value)), consumed
+}
+
+// hasContinuationBackslash reports whether value ends in a backslash that
+// isn't itself escaped (an even run of backslashes before it).
+func hasContinuationBackslash(value string) bool {
+	if !strings.HasSuffix(value, `\`) {
+		return false
+	}
+	return countTrailingBackslashes(value)%2 == 1
+}
+
+// opensTripleQuote reports whether the trimmed value starts a triple-quoted
+// block (`"""` or `'''`), returning the quote marker and whatever text
+// follows it on the same line.
+func opensTripleQuote(rawValue string) (quote string, body string, ok bool) {
+	trimmed := strings.TrimSpace(rawValue)
+	for _, q := range []string{`"""`, "'''"} {
+		if strings.HasPrefix(trimmed, q) {
+			return q, trimmed[len(q):], true
+		}
+	}
+	return "", "", false
+}
+
+// collectTripleQuoted gathers raw lines verbatim, with no escape or comment
+// processing, until one ends with quote. The quote markers are stripped.
+func collectTripleQuoted(quote string, body string, lines []string, from int) (string, int) {
+	if strings.HasSuffix(body, quote) {
+		return body[:len(body)-len(quote)], 0
+	}
+
+	var b strings.Builder
+	b.WriteString(body)
+	consumed := 0
+	for idx := from; idx < len(lines); idx++ {
+		consumed++
+		line := lines[idx]
+		b.WriteByte('\n')
+		if strings.HasSuffix(line, quote) {
+			b.WriteString(line[:len(line)-len(quote)])
+			return b.String(), consumed
+		}
+		b.WriteString(line)
+	}
+	// Unterminated block: return whatever was collected.
+	return b.String(), consumed
+}
@@ -45,12 +45,123 @@ func TestFromByte(t *testing.T) {
 		t.Error("Only 5 themes are valid")
 	}
 
-    sections := ini.GetSectionValues()
-    // "", "gui settins", "theme"
-    if len(sections) != 3 {
-        t.Logf("Sections... %s\n", sections)
-        t.Error("Sections len is not 3")
-    }
+	sections := ini.GetSectionValues()
+	// "", "gui settins", "theme"
+	if len(sections) != 3 {
+		t.Logf("Sections... %s\n", sections)
+		t.Error("Sections len is not 3")
+	}
+}
+
+type guiSettings struct {
+	Width       int      `ini:"width"`
+	Height      int      `ini:"height"`
+	ScaleFactor float32  `ini:"scale factor"`
+	ValidThemes []string `ini:"valid themes" delim:","`
+}
+
+type theme struct {
+	UseSystemTheme bool `ini:"use system theme"`
+	AccentColor    int  `ini:"accent color"`
+}
+
+type config struct {
+	InfoText    string      `ini:"info text"`
+	GuiSettings guiSettings `section:"gui settings"`
+	Theme       theme       `section:"theme"`
+}
+
+func TestMapTo(t *testing.T) {
+	ini := goini.NewIni()
+	ini.LoadFromBytes([]byte(content))
+
+	var cfg config
+	if err := ini.MapTo(&cfg); err != nil {
+		t.Fatalf("MapTo failed: %s", err)
+	}
+
+	if cfg.GuiSettings.Width != 1920 {
+		t.Error("width must be 1920")
+	}
+	if cfg.Theme.AccentColor != 16_711_935 {
+		t.Error("accent color must be 0xff00ff")
+	}
+	if len(cfg.GuiSettings.ValidThemes) != 5 {
+		t.Logf("Valid themes %s\n", cfg.GuiSettings.ValidThemes)
+		t.Error("Only 5 themes are valid")
+	}
+
+	out := goini.NewIni()
+	if err := out.ReflectFrom(&cfg); err != nil {
+		t.Fatalf("ReflectFrom failed: %s", err)
+	}
+	if out.GetInt("gui settings", "width", 0) != 1920 {
+		t.Error("ReflectFrom must round-trip width")
+	}
+}
+
+type wideFields struct {
+	Big      int64  `ini:"big"`
+	Unsigned uint64 `ini:"unsigned"`
+}
+
+func TestMapToWideInts(t *testing.T) {
+	ini := goini.NewIni()
+	if err := ini.LoadFromBytes([]byte("big=5000000000\nunsigned=5000000000\n")); err != nil {
+		t.Fatalf("LoadFromBytes failed: %s", err)
+	}
+
+	var fields wideFields
+	if err := ini.MapTo(&fields); err != nil {
+		t.Fatalf("MapTo failed: %s", err)
+	}
+	if fields.Big != 5_000_000_000 {
+		t.Errorf("int64 field must not be truncated to 32 bits, got %d", fields.Big)
+	}
+	if fields.Unsigned != 5_000_000_000 {
+		t.Errorf("uint64 field must not be truncated to 32 bits, got %d", fields.Unsigned)
+	}
+}
+
+type piField struct {
+	Pi float64 `ini:"pi"`
+}
+
+func TestMapToFloat64Precision(t *testing.T) {
+	ini := goini.NewIni()
+	if err := ini.LoadFromBytes([]byte("pi=3.14159265358979\n")); err != nil {
+		t.Fatalf("LoadFromBytes failed: %s", err)
+	}
+
+	var fields piField
+	if err := ini.MapTo(&fields); err != nil {
+		t.Fatalf("MapTo failed: %s", err)
+	}
+	if fields.Pi != 3.14159265358979 {
+		t.Errorf("float64 field must not be truncated to float32 precision, got %v", fields.Pi)
+	}
+}
+
+func TestReflectFromFloat32Precision(t *testing.T) {
+	ini := goini.NewIni()
+	ini.LoadFromBytes([]byte(content))
+
+	var cfg config
+	if err := ini.MapTo(&cfg); err != nil {
+		t.Fatalf("MapTo failed: %s", err)
+	}
+
+	out := goini.NewIni()
+	if err := out.ReflectFrom(&cfg); err != nil {
+		t.Fatalf("ReflectFrom failed: %s", err)
+	}
+	saved, err := out.SaveToBytes()
+	if err != nil {
+		t.Fatalf("SaveToBytes failed: %s", err)
+	}
+	if got := out.GetString("gui settings", "scale factor", ""); got != "1.33" {
+		t.Errorf("float32 field must round-trip at float32 precision, got %q (saved: %s)", got, saved)
+	}
 }
 
 func TestFromFile(t *testing.T) {
@@ -73,3 +184,229 @@ func TestFromFile(t *testing.T) {
 		t.Error("color must be 0xff00ff")
 	}
 }
+
+func TestSaveAndReload(t *testing.T) {
+	ini := goini.NewIni()
+	ini.LoadFromBytes([]byte(content))
+
+	ini.SetInt("gui settings", "width", 1024)
+	ini.SetBool("theme", "use system theme", true)
+	ini.Set("theme", "new key", "new value")
+	ini.DeleteKey("gui settings", "scale factor2")
+
+	saved, err := ini.SaveToBytes()
+	if err != nil {
+		t.Fatalf("SaveToBytes failed: %s", err)
+	}
+
+	reloaded := goini.NewIni()
+	reloaded.LoadFromBytes(saved)
+
+	if reloaded.GetInt("gui settings", "width", 0) != 1024 {
+		t.Logf("Saved ini:\n%s", saved)
+		t.Error("width must be 1024 after save/reload")
+	}
+	if !reloaded.GetBool("theme", "use system theme", false) {
+		t.Error("use system theme must be true after save/reload")
+	}
+	if reloaded.GetString("theme", "new key", "") != "new value" {
+		t.Error("new key must survive save/reload")
+	}
+	if reloaded.KeyExists("gui settings", "scale factor2") {
+		t.Error("scale factor2 must have been deleted")
+	}
+}
+
+var escapedContent = `
+[style]
+rule=color: %s\; background-color: %s
+path=C:\\Users\\demo
+literal=a=b=c
+commented=value ; trailing comment
+`
+
+func TestInlineCommentsAndEscapes(t *testing.T) {
+	ini := goini.NewIni()
+	ini.LoadFromBytes([]byte(escapedContent))
+
+	if got := ini.GetString("style", "rule", ""); got != `color: %s; background-color: %s` {
+		t.Errorf("escaped semicolon not restored, got %q", got)
+	}
+	if got := ini.GetString("style", "path", ""); got != `C:\Users\demo` {
+		t.Errorf("escaped backslashes not restored, got %q", got)
+	}
+	if got := ini.GetString("style", "literal", ""); got != "a=b=c" {
+		t.Errorf("value with '=' must be kept whole, got %q", got)
+	}
+	if got := ini.GetString("style", "commented", ""); got != "value" {
+		t.Errorf("inline comment must be stripped, got %q", got)
+	}
+
+	raw := goini.NewIniWithOptions(goini.ParseOptions{DisableInlineComments: true})
+	raw.LoadFromBytes([]byte(escapedContent))
+	if got := raw.GetString("style", "commented", ""); got != "value ; trailing comment" {
+		t.Errorf("DisableInlineComments must keep ';' literal, got %q", got)
+	}
+}
+
+func TestBOM(t *testing.T) {
+	utf8BOM := append([]byte{0xEF, 0xBB, 0xBF}, []byte("[section]\nkey=value\n")...)
+
+	ini := goini.NewIni()
+	if err := ini.LoadFromBytes(utf8BOM); err != nil {
+		t.Fatalf("LoadFromBytes with UTF-8 BOM failed: %s", err)
+	}
+	if got := ini.GetString("section", "key", ""); got != "value" {
+		t.Errorf("UTF-8 BOM must be stripped before parsing, got %q", got)
+	}
+
+	// UTF-16LE BOM followed by "key=value\n" encoded two bytes per rune.
+	utf16LE := []byte{0xFF, 0xFE}
+	for _, r := range "key=value\n" {
+		utf16LE = append(utf16LE, byte(r), 0)
+	}
+	utf16Ini := goini.NewIni()
+	if err := utf16Ini.LoadFromBytes(utf16LE); err != nil {
+		t.Fatalf("LoadFromBytes with UTF-16LE BOM failed: %s", err)
+	}
+	if got := utf16Ini.GetString("", "key", ""); got != "value" {
+		t.Errorf("UTF-16LE content must be transcoded before parsing, got %q", got)
+	}
+}
+
+var multilineContent = `
+[lists]
+valid themes=dark,\
+light,\
+classic
+
+[blocks]
+license="""
+Copyright (c) Example
+All rights reserved.
+"""
+raw json='''{"a": 1, "b": [1,2]}'''
+
+[after]
+key=value
+`
+
+func TestMultilineValues(t *testing.T) {
+	ini := goini.NewIni()
+	if err := ini.LoadFromBytes([]byte(multilineContent)); err != nil {
+		t.Fatalf("LoadFromBytes failed: %s", err)
+	}
+
+	themes := ini.GetStringSlice("lists", "valid themes", "NULL", ",")
+	if len(themes) != 3 || themes[2] != "classic" {
+		t.Errorf("continuation must join into one value, got %v", themes)
+	}
+
+	license := ini.GetString("blocks", "license", "")
+	if license != "\nCopyright (c) Example\nAll rights reserved.\n" {
+		t.Errorf("triple-quoted block not collected verbatim, got %q", license)
+	}
+
+	rawJSON := ini.GetString("blocks", "raw json", "")
+	if rawJSON != `{"a": 1, "b": [1,2]}` {
+		t.Errorf("single-line triple-quoted value mismatch, got %q", rawJSON)
+	}
+
+	if ini.GetString("after", "key", "") != "value" {
+		t.Error("parsing must resume correctly after a multi-line value")
+	}
+}
+
+var duplicateKeysContent = `
+[backends]
+host=db1.example.com
+host=db2.example.com
+port=5432
+host=db3.example.com
+`
+
+func TestDuplicateKeys(t *testing.T) {
+	ini := goini.NewIni()
+	if err := ini.LoadFromBytes([]byte(duplicateKeysContent)); err != nil {
+		t.Fatalf("LoadFromBytes failed: %s", err)
+	}
+
+	hosts := ini.GetStringValues("backends", "host")
+	want := []string{"db1.example.com", "db2.example.com", "db3.example.com"}
+	if len(hosts) != len(want) {
+		t.Fatalf("GetStringValues length mismatch, got %v", hosts)
+	}
+	for idx, h := range want {
+		if hosts[idx] != h {
+			t.Errorf("GetStringValues[%d] = %q, want %q", idx, hosts[idx], h)
+		}
+	}
+
+	if got := ini.GetString("backends", "host", ""); got != "db3.example.com" {
+		t.Errorf("GetString must keep returning the last-declared value, got %q", got)
+	}
+
+	keys := ini.Keys("backends")
+	if len(keys) != 2 || keys[0] != "host" || keys[1] != "port" {
+		t.Errorf("Keys must list each key once in file order, got %v", keys)
+	}
+}
+
+func TestDuplicateKeysSurviveSave(t *testing.T) {
+	ini := goini.NewIni()
+	if err := ini.LoadFromBytes([]byte(duplicateKeysContent)); err != nil {
+		t.Fatalf("LoadFromBytes failed: %s", err)
+	}
+
+	saved, err := ini.SaveToBytes()
+	if err != nil {
+		t.Fatalf("SaveToBytes failed: %s", err)
+	}
+
+	reloaded := goini.NewIni()
+	if err := reloaded.LoadFromBytes(saved); err != nil {
+		t.Fatalf("LoadFromBytes of saved data failed: %s", err)
+	}
+
+	hosts := reloaded.GetStringValues("backends", "host")
+	want := []string{"db1.example.com", "db2.example.com", "db3.example.com"}
+	if len(hosts) != len(want) {
+		t.Logf("Saved ini:\n%s", saved)
+		t.Fatalf("GetStringValues length mismatch after save/reload, got %v", hosts)
+	}
+	for idx, h := range want {
+		if hosts[idx] != h {
+			t.Errorf("GetStringValues[%d] = %q, want %q", idx, hosts[idx], h)
+		}
+	}
+}
+
+func TestSetCollapsesDuplicateKeys(t *testing.T) {
+	ini := goini.NewIni()
+	if err := ini.LoadFromBytes([]byte(duplicateKeysContent)); err != nil {
+		t.Fatalf("LoadFromBytes failed: %s", err)
+	}
+
+	ini.Set("backends", "host", "new.example.com")
+
+	hosts := ini.GetStringValues("backends", "host")
+	if len(hosts) != 1 || hosts[0] != "new.example.com" {
+		t.Errorf("Set on a duplicated key must collapse it to a single value, got %v", hosts)
+	}
+	if got := ini.GetString("backends", "host", ""); got != "new.example.com" {
+		t.Errorf("GetString must reflect the new value, got %q", got)
+	}
+
+	saved, err := ini.SaveToBytes()
+	if err != nil {
+		t.Fatalf("SaveToBytes failed: %s", err)
+	}
+	reloaded := goini.NewIni()
+	if err := reloaded.LoadFromBytes(saved); err != nil {
+		t.Fatalf("LoadFromBytes of saved data failed: %s", err)
+	}
+	if hosts := reloaded.GetStringValues("backends", "host"); len(hosts) != 1 || hosts[0] != "new.example.com" {
+		t.Logf("Saved ini:\n%s", saved)
+		t.Errorf("collapsed key must not resurrect stale duplicates on save, got %v", hosts)
+	}
+}
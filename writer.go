@@ -0,0 +1,123 @@
+package goini
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Set stores value under section/key, creating the section if it doesn't
+// exist yet. An empty section "" is valid and behaves like the keys found
+// before the first [section] header on load.
+func (i *Ini) Set(section string, key string, value string) {
+	i.setRaw(section, key, value)
+	i.meta.addKey(section, key)
+}
+
+// SetInt stores value, formatted as base 10, under section/key.
+func (i *Ini) SetInt(section string, key string, value int) {
+	i.Set(section, key, strconv.Itoa(value))
+}
+
+// SetFloat stores value under section/key.
+func (i *Ini) SetFloat(section string, key string, value float32) {
+	i.Set(section, key, strconv.FormatFloat(float64(value), 'f', -1, 32))
+}
+
+// SetBool stores value, as "true" or "false", under section/key.
+func (i *Ini) SetBool(section string, key string, value bool) {
+	i.Set(section, key, strconv.FormatBool(value))
+}
+
+// SetStringSlice stores value under section/key, joined with sep. Use the
+// same sep with GetStringSlice to read it back.
+func (i *Ini) SetStringSlice(section string, key string, value []string, sep string) {
+	i.Set(section, key, strings.Join(value, sep))
+}
+
+// DeleteKey removes key, and every duplicate declaration of it, from
+// section. It's a no-op if the key or the section doesn't exist.
+func (i *Ini) DeleteKey(section string, key string) {
+	if kv, exists := i.sections[section]; exists {
+		delete(kv.Item, key)
+		filtered := kv.pairs[:0]
+		for _, pair := range kv.pairs {
+			if pair.Key != key {
+				filtered = append(filtered, pair)
+			}
+		}
+		kv.pairs = filtered
+		i.sections[section] = kv
+	}
+	i.meta.removeKey(section, key)
+}
+
+// DeleteSection removes section and all of its keys. It's a no-op if the
+// section doesn't exist.
+func (i *Ini) DeleteSection(section string) {
+	delete(i.sections, section)
+	i.meta.removeSection(section)
+}
+
+// SaveToFile serializes the in-memory sections/keys and writes them to
+// path, overwriting it if it already exists.
+func (i *Ini) SaveToFile(path string) error {
+	buffer, err := i.SaveToBytes()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, buffer, 0644)
+}
+
+// SaveToBytes serializes the in-memory sections/keys. The empty section ""
+// is emitted first with no header, followed by each named section as
+// "[name]" and its key/value pairs. Comments and blank lines from a loaded
+// file are reproduced next to the section or key they preceded.
+func (i *Ini) SaveToBytes() ([]byte, error) {
+	var buf bytes.Buffer
+
+	writeComments := func(section string, key string) {
+		for _, line := range i.meta.comments[commentKey(section, key)] {
+			buf.WriteString(line)
+			buf.WriteByte('\n')
+		}
+	}
+
+	writeKeys := func(section string) {
+		seenComments := make(map[string]bool)
+		for _, pair := range i.sections[section].pairs {
+			// A repeated key's comments were all folded into its first
+			// occurrence on load; only print them once, ahead of it.
+			if !seenComments[pair.Key] {
+				seenComments[pair.Key] = true
+				writeComments(section, pair.Key)
+			}
+			fmt.Fprintf(&buf, "%s = %s\n", pair.Key, escapeValue(pair.Value))
+		}
+	}
+
+	writeComments("", "")
+	writeKeys("")
+
+	for _, section := range i.meta.sectionOrder {
+		if section == "" {
+			continue
+		}
+		if _, exists := i.sections[section]; !exists {
+			continue // deleted since load
+		}
+		buf.WriteByte('\n')
+		writeComments(section, "")
+		fmt.Fprintf(&buf, "[%s]\n", section)
+		writeKeys(section)
+	}
+
+	for _, line := range i.meta.trailing {
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+
+	return buf.Bytes(), nil
+}